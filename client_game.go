@@ -0,0 +1,92 @@
+package main
+
+import (
+	"fmt"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+
+	pongnet "github.com/sumomo-99/go-pong-ai/net"
+)
+
+// keyboardActionSource drives a paddle from a single up/down key pair,
+// used when a process wants a locally-controlled paddle without an
+// Agent behind it (e.g. -role=human on the server).
+type keyboardActionSource struct {
+	upKey   ebiten.Key
+	downKey ebiten.Key
+}
+
+func (s *keyboardActionSource) SelectAction(state int) int {
+	switch {
+	case ebiten.IsKeyPressed(s.upKey):
+		return ActionUp
+	case ebiten.IsKeyPressed(s.downKey):
+		return ActionDown
+	default:
+		return ActionStay
+	}
+}
+
+// remoteClientGame is the ebiten.Game run by a -connect process: it owns
+// no simulation of its own, only the latest GameState streamed from the
+// server and the local player's paddle-2 input, which it forwards every
+// tick.
+type remoteClientGame struct {
+	client *pongnet.Client
+	state  pongnet.GameState
+	stateCh chan pongnet.GameState
+}
+
+func newRemoteClientGame(client *pongnet.Client) *remoteClientGame {
+	g := &remoteClientGame{
+		client:  client,
+		stateCh: make(chan pongnet.GameState, 1),
+	}
+	go func() {
+		for {
+			state, err := client.ReceiveState()
+			if err != nil {
+				return
+			}
+			select {
+			case g.stateCh <- state:
+			default:
+				<-g.stateCh
+				g.stateCh <- state
+			}
+		}
+	}()
+	return g
+}
+
+func (g *remoteClientGame) Update() error {
+	select {
+	case g.state = <-g.stateCh:
+	default:
+	}
+
+	action := ActionStay
+	switch {
+	case ebiten.IsKeyPressed(ebiten.KeyUp):
+		action = ActionUp
+	case ebiten.IsKeyPressed(ebiten.KeyDown):
+		action = ActionDown
+	}
+	return g.client.SendInput(action)
+}
+
+func (g *remoteClientGame) Draw(screen *ebiten.Image) {
+	screen.Fill(color.RGBA{0, 0, 0, 0xff})
+	ebitenutil.DrawRect(screen, 50, g.state.Paddle1Y, paddleWidth, paddleHeight, color.White)
+	ebitenutil.DrawRect(screen, float64(g.client.Handshake.ScreenWidth-50-paddleWidth), g.state.Paddle2Y, paddleWidth, paddleHeight, color.White)
+	ebitenutil.DrawCircle(screen, g.state.BallX, g.state.BallY, ballRadius, color.White)
+
+	scoreText := fmt.Sprintf("AI 1: %d  AI 2: %d", g.state.Score1, g.state.Score2)
+	ebitenutil.DebugPrint(screen, scoreText)
+}
+
+func (g *remoteClientGame) Layout(outsideWidth, outsideHeight int) (int, int) {
+	return g.client.Handshake.ScreenWidth, g.client.Handshake.ScreenHight
+}
@@ -0,0 +1,342 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/rand"
+	"os"
+)
+
+// dqnStateSize is the length of the continuous state vector DQNAgent
+// operates on: (ballX, ballY, ballVelX, ballVelY, paddleY, opponentY),
+// each normalized to [-1, 1].
+const dqnStateSize = 6
+
+// dqnHiddenSize is the width of both hidden layers of the online/target
+// MLP (6 -> 64 -> 64 -> 3).
+const dqnHiddenSize = 64
+
+// dqnNumActions mirrors the ActionUp/ActionDown/ActionStay action space
+// used by the tabular Agent.
+const dqnNumActions = 3
+
+const (
+	dqnReplayCapacity    = 50000
+	dqnBatchSize         = 32
+	dqnTargetUpdateEvery = 1000
+)
+
+// transition is one (s, a, r, s', done) step stored in replay memory.
+type transition struct {
+	state     [dqnStateSize]float64
+	action    int
+	reward    float64
+	nextState [dqnStateSize]float64
+	done      bool
+}
+
+// replayMemory is a fixed-capacity ring buffer of transitions sampled
+// uniformly for minibatch training.
+type replayMemory struct {
+	buffer []transition
+	next   int
+	size   int
+}
+
+func newReplayMemory(capacity int) *replayMemory {
+	return &replayMemory{buffer: make([]transition, capacity)}
+}
+
+func (m *replayMemory) push(t transition) {
+	m.buffer[m.next] = t
+	m.next = (m.next + 1) % len(m.buffer)
+	if m.size < len(m.buffer) {
+		m.size++
+	}
+}
+
+func (m *replayMemory) sample(batchSize int) []transition {
+	batch := make([]transition, batchSize)
+	for i := range batch {
+		batch[i] = m.buffer[rand.Intn(m.size)]
+	}
+	return batch
+}
+
+// mlp is a small fully-connected network (6 -> 64 -> 64 -> 3) with ReLU
+// hidden activations and a linear output layer producing one Q-value per
+// action. Weight matrices are stored input-major (w[i][j] connects input
+// neuron i to output neuron j) so forward/backward are plain nested loops.
+type mlp struct {
+	W1 [][]float64
+	B1 []float64
+	W2 [][]float64
+	B2 []float64
+	W3 [][]float64
+	B3 []float64
+}
+
+func newMLP() *mlp {
+	return &mlp{
+		W1: randWeights(dqnStateSize, dqnHiddenSize),
+		B1: make([]float64, dqnHiddenSize),
+		W2: randWeights(dqnHiddenSize, dqnHiddenSize),
+		B2: make([]float64, dqnHiddenSize),
+		W3: randWeights(dqnHiddenSize, dqnNumActions),
+		B3: make([]float64, dqnNumActions),
+	}
+}
+
+// randWeights returns a rows x cols matrix with small He-scaled random
+// weights, drawn uniformly from [-sqrt(2/rows), sqrt(2/rows)].
+func randWeights(rows, cols int) [][]float64 {
+	scale := math.Sqrt(2.0 / float64(rows))
+	w := make([][]float64, rows)
+	for i := range w {
+		w[i] = make([]float64, cols)
+		for j := range w[i] {
+			w[i][j] = (rand.Float64()*2 - 1) * scale
+		}
+	}
+	return w
+}
+
+func (m *mlp) clone() *mlp {
+	return &mlp{
+		W1: cloneMatrix(m.W1), B1: cloneVector(m.B1),
+		W2: cloneMatrix(m.W2), B2: cloneVector(m.B2),
+		W3: cloneMatrix(m.W3), B3: cloneVector(m.B3),
+	}
+}
+
+func cloneMatrix(m [][]float64) [][]float64 {
+	out := make([][]float64, len(m))
+	for i, row := range m {
+		out[i] = cloneVector(row)
+	}
+	return out
+}
+
+func cloneVector(v []float64) []float64 {
+	out := make([]float64, len(v))
+	copy(out, v)
+	return out
+}
+
+// forwardCache holds every layer's pre/post-activation values so backward
+// can compute gradients without redoing the forward pass.
+type forwardCache struct {
+	input []float64
+	z1    []float64
+	a1    []float64
+	z2    []float64
+	a2    []float64
+	out   []float64
+}
+
+func matVec(w [][]float64, x []float64, b []float64) []float64 {
+	out := cloneVector(b)
+	for i, xi := range x {
+		row := w[i]
+		for j, wij := range row {
+			out[j] += xi * wij
+		}
+	}
+	return out
+}
+
+func relu(v []float64) []float64 {
+	out := make([]float64, len(v))
+	for i, x := range v {
+		if x > 0 {
+			out[i] = x
+		}
+	}
+	return out
+}
+
+func reluDeriv(z float64) float64 {
+	if z > 0 {
+		return 1
+	}
+	return 0
+}
+
+func (m *mlp) forward(input []float64) forwardCache {
+	z1 := matVec(m.W1, input, m.B1)
+	a1 := relu(z1)
+	z2 := matVec(m.W2, a1, m.B2)
+	a2 := relu(z2)
+	out := matVec(m.W3, a2, m.B3)
+	return forwardCache{input: input, z1: z1, a1: a1, z2: z2, a2: a2, out: out}
+}
+
+// backward performs one SGD step that pulls out[action] toward target,
+// leaving the other action outputs untouched (their gradient is zero).
+func (m *mlp) backward(c forwardCache, action int, target, learningRate float64) {
+	delta3 := make([]float64, dqnNumActions)
+	delta3[action] = 2 * (c.out[action] - target)
+
+	delta2 := make([]float64, dqnHiddenSize)
+	for i := 0; i < dqnHiddenSize; i++ {
+		sum := 0.0
+		for j, d := range delta3 {
+			sum += d * m.W3[i][j]
+		}
+		delta2[i] = sum * reluDeriv(c.z2[i])
+	}
+
+	delta1 := make([]float64, dqnHiddenSize)
+	for i := 0; i < dqnHiddenSize; i++ {
+		sum := 0.0
+		for j, d := range delta2 {
+			sum += d * m.W2[i][j]
+		}
+		delta1[i] = sum * reluDeriv(c.z1[i])
+	}
+
+	for i := 0; i < dqnHiddenSize; i++ {
+		for j, d := range delta3 {
+			m.W3[i][j] -= learningRate * d * c.a2[i]
+		}
+	}
+	for j, d := range delta3 {
+		m.B3[j] -= learningRate * d
+	}
+
+	for i := 0; i < dqnHiddenSize; i++ {
+		for j, d := range delta2 {
+			m.W2[i][j] -= learningRate * d * c.a1[i]
+		}
+	}
+	for j, d := range delta2 {
+		m.B2[j] -= learningRate * d
+	}
+
+	for i := 0; i < dqnStateSize; i++ {
+		for j, d := range delta1 {
+			m.W1[i][j] -= learningRate * d * c.input[i]
+		}
+	}
+	for j, d := range delta1 {
+		m.B1[j] -= learningRate * d
+	}
+}
+
+// DQNAgent replaces the tabular Agent's discretized Q-table with a small
+// MLP operating on the continuous state vector described in getStateVector,
+// trained off a replay memory against a periodically-synced target
+// network, selectable via -agent=dqn.
+type DQNAgent struct {
+	paddleID         int
+	online           *mlp
+	target           *mlp
+	memory           *replayMemory
+	learningRate     float64
+	discountRate     float64
+	epsilon          float64
+	batchSize        int
+	targetUpdateEvery int
+	updateCount      int
+}
+
+func NewDQNAgent(id int, learningRate, discountRate, epsilon float64) *DQNAgent {
+	online := newMLP()
+	return &DQNAgent{
+		paddleID:          id,
+		online:            online,
+		target:            online.clone(),
+		memory:            newReplayMemory(dqnReplayCapacity),
+		learningRate:      learningRate,
+		discountRate:      discountRate,
+		epsilon:           epsilon,
+		batchSize:         dqnBatchSize,
+		targetUpdateEvery: dqnTargetUpdateEvery,
+	}
+}
+
+// SelectAction is the DQN analogue of Agent.selectAction: ε-greedy over
+// the online network's Q-value estimates for state.
+func (a *DQNAgent) SelectAction(state [dqnStateSize]float64) int {
+	if rand.Float64() < a.epsilon {
+		return rand.Intn(dqnNumActions)
+	}
+	q := a.online.forward(state[:]).out
+	bestAction := 0
+	for action := 1; action < dqnNumActions; action++ {
+		if q[action] > q[bestAction] {
+			bestAction = action
+		}
+	}
+	return bestAction
+}
+
+// Remember stores one transition and runs a minibatch training step once
+// enough experience has accumulated, hard-copying the target network
+// every targetUpdateEvery updates.
+func (a *DQNAgent) Remember(state [dqnStateSize]float64, action int, reward float64, nextState [dqnStateSize]float64, done bool) {
+	a.memory.push(transition{state: state, action: action, reward: reward, nextState: nextState, done: done})
+	if a.memory.size < a.batchSize {
+		return
+	}
+
+	for _, t := range a.memory.sample(a.batchSize) {
+		nextQ := a.target.forward(t.nextState[:]).out
+		maxNextQ := nextQ[0]
+		for _, q := range nextQ[1:] {
+			if q > maxNextQ {
+				maxNextQ = q
+			}
+		}
+		target := t.reward
+		if !t.done {
+			target += a.discountRate * maxNextQ
+		}
+		cache := a.online.forward(t.state[:])
+		a.online.backward(cache, t.action, target, a.learningRate)
+	}
+
+	a.updateCount++
+	if a.updateCount%a.targetUpdateEvery == 0 {
+		a.target = a.online.clone()
+	}
+}
+
+// SaveWeights persists the online network's weights, replacing
+// Agent.SaveQTable when DQN mode is active.
+func (a *DQNAgent) SaveWeights(filename string) error {
+	file, err := os.Create(filename)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer file.Close()
+
+	if err := json.NewEncoder(file).Encode(a.online); err != nil {
+		return fmt.Errorf("failed to encode DQN weights: %w", err)
+	}
+	fmt.Printf("DQN weights for agent %d saved to %s\n", a.paddleID, filename)
+	return nil
+}
+
+// LoadWeights loads previously saved online weights and resyncs the
+// target network to match, replacing Agent.LoadQTable when DQN mode is
+// active.
+func (a *DQNAgent) LoadWeights(filename string) error {
+	file, err := os.Open(filename)
+	if err != nil {
+		if os.IsNotExist(err) {
+			fmt.Printf("DQN weights file %s not found for agent %d. Starting from random initialization.\n", filename, a.paddleID)
+			return nil
+		}
+		return fmt.Errorf("failed to open file: %w", err)
+	}
+	defer file.Close()
+
+	if err := json.NewDecoder(file).Decode(a.online); err != nil {
+		return fmt.Errorf("failed to decode DQN weights: %w", err)
+	}
+	a.target = a.online.clone()
+	fmt.Printf("DQN weights for agent %d loaded from %s\n", a.paddleID, filename)
+	return nil
+}
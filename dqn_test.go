@@ -0,0 +1,24 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestMLPBackwardMovesOutputTowardTarget(t *testing.T) {
+	m := newMLP()
+	input := []float64{0.1, -0.2, 0.3, -0.4, 0.5, -0.6}
+	const action = 1
+	const target = 2.0
+
+	before := m.forward(input).out[action]
+	for i := 0; i < 50; i++ {
+		cache := m.forward(input)
+		m.backward(cache, action, target, 0.01)
+	}
+	after := m.forward(input).out[action]
+
+	if math.Abs(target-after) >= math.Abs(target-before) {
+		t.Fatalf("backward did not move output toward target: before=%.4f after=%.4f target=%.4f", before, after, target)
+	}
+}
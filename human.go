@@ -0,0 +1,81 @@
+package main
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+// setupMode wires actionSource1/actionSource2 for -mode:
+//   - "ai-vs-ai" (default): both paddles stay agent-driven.
+//   - "human-vs-ai": paddle 1 is keyboard-driven (W/S), paddle 2 stays agent-driven.
+//   - "human-vs-human": both paddles are keyboard-driven (W/S and ↑/↓).
+func setupMode(g *Game, mode string) error {
+	if g.useDQN && mode != "ai-vs-ai" {
+		log.Printf("-mode=%s has no effect in -agent=dqn mode; both paddles stay DQN-controlled", mode)
+		return nil
+	}
+
+	switch mode {
+	case "ai-vs-ai":
+		// Already wired to agent1/agent2 by NewGame.
+	case "human-vs-ai":
+		g.actionSource1 = &keyboardActionSource{upKey: ebiten.KeyW, downKey: ebiten.KeyS}
+	case "human-vs-human":
+		g.actionSource1 = &keyboardActionSource{upKey: ebiten.KeyW, downKey: ebiten.KeyS}
+		g.actionSource2 = &keyboardActionSource{upKey: ebiten.KeyUp, downKey: ebiten.KeyDown}
+	default:
+		return fmt.Errorf("unknown -mode %q, want ai-vs-ai, human-vs-ai, or human-vs-human", mode)
+	}
+	return nil
+}
+
+// handleGameControlKeys polls the debug controls available in every mode:
+// Space pauses/resumes Update, R soft-resets (ball and scores, keeping
+// Q-tables and ε), and Shift+R hard-resets (reinitializes Q-tables and ε
+// on top of a soft reset).
+func (g *Game) handleGameControlKeys() {
+	if inpututil.IsKeyJustPressed(ebiten.KeySpace) {
+		g.paused = !g.paused
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyR) {
+		if ebiten.IsKeyPressed(ebiten.KeyShiftLeft) || ebiten.IsKeyPressed(ebiten.KeyShiftRight) {
+			g.hardReset()
+		} else {
+			g.softReset()
+		}
+	}
+}
+
+// softReset resets the ball and scores for a fresh match without
+// disturbing anything the agents have learned.
+func (g *Game) softReset() {
+	g.ballX = float64(screenWidth / 2)
+	g.ballY = float64(screenHight / 2)
+	g.ballVelX = ballSpeedX
+	g.ballVelY = ballSpeedY
+	g.score1 = 0
+	g.score2 = 0
+	g.rallyLen = 0
+}
+
+// hardReset soft-resets and additionally reinitializes both agents: the
+// tabular Q-tables and ε, or the DQN networks and replay memory.
+func (g *Game) hardReset() {
+	g.softReset()
+
+	numStates := ballXDivisions * ballYDivisions * paddleYDivisions * 2 * 2 * 3
+	g.agent1.epsilon = initialEpsilon
+	g.agent2.epsilon = initialEpsilon
+	g.agent1.initializeQTable(numStates, dqnNumActions)
+	g.agent2.initializeQTable(numStates, dqnNumActions)
+
+	if g.useDQN {
+		g.dqnAgent1 = NewDQNAgent(1, learningRate, discountRate, initialEpsilon)
+		g.dqnAgent2 = NewDQNAgent(2, learningRate, discountRate, initialEpsilon)
+	}
+
+	g.episodeCount = 0
+}
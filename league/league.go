@@ -0,0 +1,90 @@
+package league
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// kFactor controls how much a single match result moves a policy's Elo
+// rating; 32 is the standard FIDE value for active players.
+const kFactor = 32.0
+
+// League holds a pool of snapshotted policies and their Elo ratings.
+type League struct {
+	pool []*Policy
+}
+
+// NewLeague returns an empty league.
+func NewLeague() *League {
+	return &League{}
+}
+
+// Add registers a policy in the pool.
+func (l *League) Add(p *Policy) {
+	l.pool = append(l.pool, p)
+}
+
+// Size reports how many policies are in the pool.
+func (l *League) Size() int {
+	return len(l.pool)
+}
+
+// RandomPair picks two distinct policies from the pool at random. It
+// panics if the pool has fewer than two policies.
+func (l *League) RandomPair() (*Policy, *Policy) {
+	if len(l.pool) < 2 {
+		panic("league: RandomPair requires at least 2 policies in the pool")
+	}
+	i := rand.Intn(len(l.pool))
+	j := rand.Intn(len(l.pool) - 1)
+	if j >= i {
+		j++
+	}
+	return l.pool[i], l.pool[j]
+}
+
+// RecordResult updates a and b's Elo ratings given the match outcome from
+// a's perspective: scoreA is 1 for a win, 0.5 for a draw, 0 for a loss.
+func (l *League) RecordResult(a, b *Policy, scoreA float64) {
+	expectedA := 1.0 / (1.0 + math.Pow(10, (b.Rating-a.Rating)/400))
+	expectedB := 1.0 - expectedA
+	a.Rating += kFactor * (scoreA - expectedA)
+	b.Rating += kFactor * ((1 - scoreA) - expectedB)
+}
+
+// MatchFunc plays one headless match between two policies and returns
+// the result from a's perspective (1 win, 0.5 draw, 0 loss). The actual
+// simulation lives in package main, which knows how to turn a Policy's
+// Q-table into paddle behavior; League only tracks ratings.
+type MatchFunc func(a, b *Policy) (scoreA float64)
+
+// RunRandomMatches plays n matches between random pairs from the pool,
+// updating Elo ratings after each, then prints the resulting leaderboard.
+func (l *League) RunRandomMatches(n int, play MatchFunc) {
+	for i := 0; i < n; i++ {
+		a, b := l.RandomPair()
+		scoreA := play(a, b)
+		l.RecordResult(a, b, scoreA)
+	}
+	l.PrintLeaderboard()
+}
+
+// Leaderboard returns the pool sorted by descending Elo rating.
+func (l *League) Leaderboard() []*Policy {
+	sorted := make([]*Policy, len(l.pool))
+	copy(sorted, l.pool)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Rating > sorted[j].Rating
+	})
+	return sorted
+}
+
+// PrintLeaderboard prints the pool ranked by Elo rating.
+func (l *League) PrintLeaderboard() {
+	fmt.Println("League leaderboard:")
+	for rank, p := range l.Leaderboard() {
+		fmt.Printf("%2d. %-24s %.1f\n", rank+1, p.Name, p.Rating)
+	}
+}
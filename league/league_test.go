@@ -0,0 +1,37 @@
+package league
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRecordResultEqualRatingsWin(t *testing.T) {
+	a := &Policy{Name: "a", Rating: 1000}
+	b := &Policy{Name: "b", Rating: 1000}
+	l := NewLeague()
+
+	l.RecordResult(a, b, 1)
+
+	const wantDelta = 16.0
+	if got := a.Rating - 1000; math.Abs(got-wantDelta) > 1e-9 {
+		t.Errorf("a.Rating changed by %.4f, want %.4f", got, wantDelta)
+	}
+	if got := 1000 - b.Rating; math.Abs(got-wantDelta) > 1e-9 {
+		t.Errorf("b.Rating changed by %.4f, want %.4f", -got, -wantDelta)
+	}
+}
+
+func TestRecordResultDrawFavorsUnderdog(t *testing.T) {
+	a := &Policy{Name: "a", Rating: 1200}
+	b := &Policy{Name: "b", Rating: 1000}
+	l := NewLeague()
+
+	l.RecordResult(a, b, 0.5)
+
+	if a.Rating >= 1200 {
+		t.Errorf("higher-rated a.Rating should drop after a draw, got %.4f", a.Rating)
+	}
+	if b.Rating <= 1000 {
+		t.Errorf("lower-rated b.Rating should rise after a draw, got %.4f", b.Rating)
+	}
+}
@@ -0,0 +1,85 @@
+// Package league runs headless self-play tournaments between snapshotted
+// policies and tracks their Elo ratings, so training can measure progress
+// against a diverse, frozen opponent pool instead of only the live
+// co-adapting agent on the other paddle.
+package league
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+)
+
+// QTable mirrors the tabular Agent's Q-table shape (state -> action ->
+// value) without depending on package main, which would create an import
+// cycle since main drives the League.
+type QTable map[int]map[int]float64
+
+// initialRating is the Elo rating assigned to a policy with no recorded
+// match history.
+const initialRating = 1000.0
+
+// Policy is one snapshotted checkpoint competing in the league.
+type Policy struct {
+	Name   string
+	Table  QTable
+	Rating float64
+}
+
+// LoadPolicy reads a checkpointed Q-table from path, named for
+// leaderboard display.
+func LoadPolicy(name, path string) (*Policy, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open snapshot %s: %w", path, err)
+	}
+	defer file.Close()
+
+	var table QTable
+	if err := json.NewDecoder(file).Decode(&table); err != nil {
+		return nil, fmt.Errorf("failed to decode snapshot %s: %w", path, err)
+	}
+	return &Policy{Name: name, Table: table, Rating: initialRating}, nil
+}
+
+// SaveSnapshot checkpoints a Q-table to path, creating any missing parent
+// directory (e.g. snapshots/).
+func SaveSnapshot(table QTable, path string) error {
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return fmt.Errorf("failed to create snapshot dir %s: %w", dir, err)
+		}
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create snapshot %s: %w", path, err)
+	}
+	defer file.Close()
+
+	if err := json.NewEncoder(file).Encode(table); err != nil {
+		return fmt.Errorf("failed to encode snapshot %s: %w", path, err)
+	}
+	return nil
+}
+
+// SelectAction greedily picks the highest-value action for state out of
+// numActions, breaking ties toward the lowest action index. Snapshotted
+// policies play greedily, not ε-greedily: the league measures the policy
+// actually learned, not its exploration noise.
+func (p *Policy) SelectAction(state, numActions int) int {
+	actions, ok := p.Table[state]
+	if !ok {
+		return rand.Intn(numActions)
+	}
+	bestAction := 0
+	bestValue := actions[0]
+	for action := 1; action < numActions; action++ {
+		if v := actions[action]; v > bestValue {
+			bestValue = v
+			bestAction = action
+		}
+	}
+	return bestAction
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/sumomo-99/go-pong-ai/league"
+)
+
+// snapshotEvery is how often (in episodes) a headless training run
+// checkpoints agent1's Q-table to snapshots/agent_ep{N}.json.
+const snapshotEvery = 50
+
+// policyActionSource drives a paddle from a frozen league.Policy's
+// greedy action, used for -vs-snapshot opponents and league tournament
+// matches.
+type policyActionSource struct {
+	policy *league.Policy
+}
+
+func (s *policyActionSource) SelectAction(state int) int {
+	return s.policy.SelectAction(state, dqnNumActions)
+}
+
+// runHeadlessTraining drives Game.Update in a tight loop with no ebiten
+// rendering for the given number of episodes (points), checkpointing
+// agent1's Q-table every snapshotEvery episodes. If vsSnapshotPath is
+// set, paddle 2 is driven by that frozen snapshot instead of co-adapting
+// live agent2, so agent1 always faces a fixed historical opponent.
+//
+// Snapshotting and -vs-snapshot both operate on league.QTable, so neither
+// has an equivalent in -agent=dqn mode: a DQN run trains both paddles'
+// networks live and only saves final weights at the end.
+func runHeadlessTraining(episodes int, agentKind, vsSnapshotPath, rewardKind, debugAddress string, seed int64) {
+	game := NewGameWithAgent(agentKind)
+	if err := setupRewardAndMetrics(game, rewardKind, debugAddress); err != nil {
+		log.Fatal(err)
+	}
+	setupReplayRecording(game, seed)
+
+	if game.useDQN {
+		if vsSnapshotPath != "" {
+			log.Println("league: -vs-snapshot has no effect in -agent=dqn mode; paddle 2 stays a co-adapting DQN")
+		}
+		log.Println("league: periodic snapshot checkpointing has no effect in -agent=dqn mode; only final DQN weights are saved")
+	} else if vsSnapshotPath != "" {
+		opponent, err := league.LoadPolicy(vsSnapshotPath, vsSnapshotPath)
+		if err != nil {
+			log.Fatal("league: failed to load -vs-snapshot opponent: ", err)
+		}
+		game.actionSource2 = &policyActionSource{policy: opponent}
+		game.skipAgent2Training = true
+	}
+
+	nextSnapshot := snapshotEvery
+	for game.episodeCount < episodes {
+		if err := game.Update(); err != nil {
+			log.Fatal("headless training: ", err)
+		}
+		if !game.useDQN && game.episodeCount >= nextSnapshot {
+			path := fmt.Sprintf("snapshots/agent_ep%d.json", game.episodeCount)
+			if err := league.SaveSnapshot(league.QTable(game.agent1.qTable), path); err != nil {
+				log.Println("league: failed to save snapshot:", err)
+			} else {
+				fmt.Printf("league: checkpointed agent1 to %s\n", path)
+			}
+			nextSnapshot += snapshotEvery
+		}
+	}
+
+	fmt.Printf("headless training complete: %d episodes, score %d-%d\n", game.episodeCount, game.score1, game.score2)
+
+	if game.useDQN {
+		if err := game.dqnAgent1.SaveWeights("agent1_dqn_weights.json"); err != nil {
+			log.Println("Error saving DQN weights for agent 1:", err)
+		}
+		if err := game.dqnAgent2.SaveWeights("agent2_dqn_weights.json"); err != nil {
+			log.Println("Error saving DQN weights for agent 2:", err)
+		}
+	} else {
+		if err := game.agent1.SaveQTable("agent1_q_table.json"); err != nil {
+			log.Println("Error saving Q-table for agent 1:", err)
+		}
+		if err := game.agent2.SaveQTable("agent2_q_table.json"); err != nil {
+			log.Println("Error saving Q-table for agent 2:", err)
+		}
+	}
+}
+
+// runLeagueTournament loads every snapshot under dir into a league.League,
+// plays matches random pairs headlessly, and prints the Elo leaderboard.
+func runLeagueTournament(dir string, matches int) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		log.Fatal("league: failed to read snapshot dir: ", err)
+	}
+
+	l := league.NewLeague()
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+		path := dir + "/" + entry.Name()
+		policy, err := league.LoadPolicy(entry.Name(), path)
+		if err != nil {
+			log.Println("league: skipping snapshot:", err)
+			continue
+		}
+		l.Add(policy)
+	}
+	if l.Size() < 2 {
+		log.Fatalf("league: need at least 2 snapshots under %s to run a tournament, found %d", dir, l.Size())
+	}
+
+	l.RunRandomMatches(matches, playHeadlessMatch)
+}
+
+// playHeadlessMatch is the league.MatchFunc that runs one no-rendering,
+// no-training match between two frozen policies until either side scores
+// matchPointLimit points.
+const matchPointLimit = 5
+
+func playHeadlessMatch(a, b *league.Policy) float64 {
+	game := NewGame()
+	game.actionSource1 = &policyActionSource{policy: a}
+	game.actionSource2 = &policyActionSource{policy: b}
+	game.skipAgent1Training = true
+	game.skipAgent2Training = true
+
+	for game.score1 < matchPointLimit && game.score2 < matchPointLimit {
+		if err := game.Update(); err != nil {
+			log.Fatal("league match: ", err)
+		}
+	}
+
+	switch {
+	case game.score1 > game.score2:
+		return 1
+	case game.score1 < game.score2:
+		return 0
+	default:
+		return 0.5
+	}
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"encoding/json"
+	"flag"
 	"fmt"
 	"image/color"
 	"log"
@@ -11,6 +12,9 @@ import (
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+
+	pongnet "github.com/sumomo-99/go-pong-ai/net"
+	"github.com/sumomo-99/go-pong-ai/replay"
 )
 
 const (
@@ -57,6 +61,107 @@ type Game struct {
 	prevAction1 int
 	prevAction2 int
 	episodeCount int
+	tick uint64
+
+	actionSource1 ActionSource
+	actionSource2 ActionSource
+
+	// netConn is non-nil when this process is the authoritative server of
+	// a networked match; Update streams the resulting GameState over it
+	// once per tick.
+	netConn *pongnet.Conn
+
+	// useDQN switches Update from the tabular agent1/agent2 path to
+	// dqnAgent1/dqnAgent2, which operate on the continuous state vector
+	// from getStateVector instead of the discretized getState id.
+	useDQN bool
+	dqnAgent1 *DQNAgent
+	dqnAgent2 *DQNAgent
+	prevStateVec1 [dqnStateSize]float64
+	prevStateVec2 [dqnStateSize]float64
+
+	// skipAgent1Training/skipAgent2Training disable that paddle's
+	// updateQValue call, used when it is driven by a frozen league
+	// snapshot (-vs-snapshot) or when two frozen policies are facing off
+	// in a league tournament match.
+	skipAgent1Training bool
+	skipAgent2Training bool
+
+	// shaper computes reward1/reward2 for the current tick; defaults to
+	// SparseShaper, matching the original hardcoded hit and score rewards.
+	shaper RewardShaper
+	rallyLen int
+
+	// episodeTDErrorSum/episodeTDErrorCount accumulate tabular TD errors
+	// across the current episode so Update can log their average when
+	// the point ends.
+	episodeTDErrorSum float64
+	episodeTDErrorCount int
+
+	// metricsLogger is non-nil once setupRewardAndMetrics has run; Update
+	// logs one EpisodeMetrics line to it per point scored.
+	metricsLogger *MetricsLogger
+
+	// replayRecorder, when non-nil, appends every tick of a training run
+	// to replays/ep{N}.rpg, rolling over to a new file each episode.
+	recordingEnabled bool
+	replaySeed       int64
+	replayRecorder   *replay.Recorder
+	recordedEpisode  int
+
+	// replayPlayer, when non-nil, puts Update into playback mode: it
+	// reads recorded frames instead of stepping the simulation.
+	replayPlayer        *replay.Player
+	replaySpeed         float64
+	replayAccum         float64
+	replayPaused        bool
+	replayStepRequested bool
+	replayFinished      bool
+	prevPauseKeyDown    bool
+	prevStepKeyDown     bool
+
+	// paused halts Update (but not rendering) while true, toggled by
+	// Space; see handleGameControlKeys.
+	paused bool
+}
+
+// ActionSource supplies the action a paddle takes for the current state,
+// decoupling Game.Update from where that decision is made: a local
+// tabular agent, a frozen league policy, the keyboard, or a remote peer
+// over the network. In -agent=dqn mode Update bypasses action sources and
+// asks dqnAgent1/dqnAgent2 directly.
+type ActionSource interface {
+	SelectAction(state int) int
+}
+
+// agentActionSource drives a paddle from a local Agent's ε-greedy policy.
+type agentActionSource struct {
+	agent *Agent
+}
+
+func (s *agentActionSource) SelectAction(state int) int {
+	return s.agent.selectAction(state)
+}
+
+// remoteActionSource drives a paddle from ClientInput frames received
+// from a connected network peer, holding the last known action between
+// frames so a slow or stalled peer doesn't stop the paddle outright.
+type remoteActionSource struct {
+	inputCh <-chan int
+	last    int
+}
+
+func newRemoteActionSource(inputCh <-chan int) *remoteActionSource {
+	return &remoteActionSource{inputCh: inputCh, last: ActionStay}
+}
+
+func (s *remoteActionSource) SelectAction(state int) int {
+	select {
+	case a := <-s.inputCh:
+		s.last = a
+	default:
+	}
+	return s.last
 }
 
 type Agent struct {
@@ -194,14 +299,22 @@ func (g *Game) getState(paddleID int) int {
 	return stateID
 }
 
+// NewGame builds a fresh game with tabular Q-learning agents. It is
+// equivalent to NewGameWithAgent("qtable").
 func NewGame() *Game {
+	return NewGameWithAgent("qtable")
+}
+
+// NewGameWithAgent builds a fresh game using either tabular Q-learning
+// ("qtable") or a DQN ("dqn") for both paddles, selected via -agent.
+func NewGameWithAgent(agentKind string) *Game {
 	agent1 := NewAgent(1, learningRate, discountRate, initialEpsilon)
 	agent2 := NewAgent(2, learningRate, discountRate, initialEpsilon)
 	numStates := ballXDivisions * ballYDivisions * paddleYDivisions * 2 * 2 * 3
 	agent1.initializeQTable(numStates, 3)
 	agent2.initializeQTable(numStates, 3)
 
-	return &Game{
+	g := &Game{
 		paddle1Y: float64(screenHight/2 - paddleHeight/2),
 		paddle2Y: float64(screenHight/2 - paddleHeight/2),
 		ballX: float64(screenWidth/2),
@@ -217,15 +330,87 @@ func NewGame() *Game {
 		prevState2: 0,
 		prevAction2: ActionStay,
 		episodeCount: 0,
+		actionSource1: &agentActionSource{agent: agent1},
+		actionSource2: &agentActionSource{agent: agent2},
+		shaper: SparseShaper{},
+	}
+
+	if agentKind == "dqn" {
+		g.useDQN = true
+		g.dqnAgent1 = NewDQNAgent(1, learningRate, discountRate, initialEpsilon)
+		g.dqnAgent2 = NewDQNAgent(2, learningRate, discountRate, initialEpsilon)
+		g.prevStateVec1 = g.getStateVector(1)
+		g.prevStateVec2 = g.getStateVector(2)
+	}
+
+	return g
+}
+
+// getStateVector is the DQNAgent analogue of getState: the continuous
+// (ballX, ballY, ballVelX, ballVelY, paddleY, opponentY) tuple normalized
+// to [-1, 1], in place of the discretized state id.
+func (g *Game) getStateVector(paddleID int) [dqnStateSize]float64 {
+	var paddleY, opponentY float64
+	if paddleID == 1 {
+		paddleY = g.paddle1Y + paddleHeight/2
+		opponentY = g.paddle2Y + paddleHeight/2
+	} else {
+		paddleY = g.paddle2Y + paddleHeight/2
+		opponentY = g.paddle1Y + paddleHeight/2
+	}
+
+	return [dqnStateSize]float64{
+		normalize(g.ballX, screenWidth),
+		normalize(g.ballY, screenHight),
+		clampNorm(g.ballVelX / ballSpeedX),
+		clampNorm(g.ballVelY / ballSpeedY),
+		normalize(paddleY, screenHight),
+		normalize(opponentY, screenHight),
+	}
+}
+
+// normalize maps v in [0, max] to [-1, 1].
+func normalize(v, max float64) float64 {
+	return (v/max)*2 - 1
+}
+
+func clampNorm(v float64) float64 {
+	switch {
+	case v > 1:
+		return 1
+	case v < -1:
+		return -1
+	default:
+		return v
 	}
 }
 
 func (g *Game) Update() error {
+	if g.replayPlayer != nil {
+		return g.updateFromReplay()
+	}
+
+	g.handleGameControlKeys()
+	if g.paused {
+		return nil
+	}
+
+	g.rollReplayFileIfNeeded()
+
 	currentState1 := g.getState(1)
 	currentState2 := g.getState(2)
 
-	action1 := g.agent1.selectAction(currentState1)
-	action2 := g.agent2.selectAction(currentState2)
+	var action1, action2 int
+	var currentStateVec1, currentStateVec2 [dqnStateSize]float64
+	if g.useDQN {
+		currentStateVec1 = g.getStateVector(1)
+		currentStateVec2 = g.getStateVector(2)
+		action1 = g.dqnAgent1.SelectAction(currentStateVec1)
+		action2 = g.dqnAgent2.SelectAction(currentStateVec2)
+	} else {
+		action1 = g.actionSource1.SelectAction(currentState1)
+		action2 = g.actionSource2.SelectAction(currentState2)
+	}
 
 	g.updatePaddlePosition(1, action1)
 	g.updatePaddlePosition(2, action2)
@@ -252,50 +437,132 @@ func (g *Game) Update() error {
 	paddle2MaxX := paddle2MinX + paddleWidth
 	paddle2MaxY := paddle2MinY + paddleHeight
 
-	reward1 := 0.0
-	reward2 := 0.0
+	paddle1Hit := false
+	paddle2Hit := false
 
 	if intersect(paddle1MinX, paddle1MinY, paddle1MaxX, paddle1MaxY, ballMinX, ballMinY, ballMaxX, ballMaxY) {
 		  g.ballVelX *= -1
-		  reward1 += 0.1
-		  reward2 -= 0.01
+		  paddle1Hit = true
 	}
 
 	if intersect(paddle2MinX, paddle2MinY, paddle2MaxX, paddle2MaxY, ballMinX, ballMinY, ballMaxX, ballMaxY) {
 		  g.ballVelX *= -1
-		  reward2 += 0.1
-		  reward1 -= 0.01
+		  paddle2Hit = true
 	}
 
+	if paddle1Hit || paddle2Hit {
+		g.rallyLen++
+	}
+
+	scored1 := false
+	scored2 := false
+	pointScored := false
 	if ballMinX < 0 {
 		g.score2++
-		reward2 += 1
-		reward1 -= 1
+		scored2 = true
+		pointScored = true
 		g.resetBall()
 	}
 	if ballMinX > screenWidth {
 		g.score1++
-		reward1 += 1
-		reward2 -= 1
+		scored1 = true
+		pointScored = true
 		g.resetBall()
 	}
 
-	g.agent1.updateQValue(g.prevState1, g.prevAction1, reward1, currentState1)
-	g.agent2.updateQValue(g.prevState2, g.prevAction2, reward2, currentState2)
+	reward1, reward2 := g.shaper.Shape(g, paddle1Hit, paddle2Hit, scored1, scored2, g.rallyLen)
+
+	if g.useDQN {
+		g.dqnAgent1.Remember(g.prevStateVec1, g.prevAction1, reward1, currentStateVec1, pointScored)
+		g.dqnAgent2.Remember(g.prevStateVec2, g.prevAction2, reward2, currentStateVec2, pointScored)
+		g.prevStateVec1 = currentStateVec1
+		g.prevStateVec2 = currentStateVec2
+	} else {
+		if !g.skipAgent1Training {
+			tdError1 := g.agent1.updateQValue(g.prevState1, g.prevAction1, reward1, currentState1)
+			g.episodeTDErrorSum += tdError1
+			g.episodeTDErrorCount++
+		}
+		if !g.skipAgent2Training {
+			tdError2 := g.agent2.updateQValue(g.prevState2, g.prevAction2, reward2, currentState2)
+			g.episodeTDErrorSum += tdError2
+			g.episodeTDErrorCount++
+		}
+	}
+
+	if pointScored && g.metricsLogger != nil {
+		avgTDError := 0.0
+		if g.episodeTDErrorCount > 0 {
+			avgTDError = g.episodeTDErrorSum / float64(g.episodeTDErrorCount)
+		}
+		g.metricsLogger.Log(EpisodeMetrics{
+			Episode:    g.episodeCount,
+			Score1:     g.score1,
+			Score2:     g.score2,
+			RallyLen:   g.rallyLen,
+			AvgTDError: avgTDError,
+			Epsilon:    g.epsilon1(),
+		})
+	}
+	if pointScored {
+		g.rallyLen = 0
+		g.episodeTDErrorSum = 0
+		g.episodeTDErrorCount = 0
+	}
 
 	g.prevState1 = currentState1
 	g.prevAction1 = action1
 	g.prevState2 = currentState2
 	g.prevAction2 = action2
 
+	g.tick++
+	if g.replayRecorder != nil {
+		if err := g.replayRecorder.RecordFrame(replay.Frame{
+			Tick:     g.tick,
+			BallX:    g.ballX,
+			BallY:    g.ballY,
+			Paddle1Y: g.paddle1Y,
+			Paddle2Y: g.paddle2Y,
+			Action1:  action1,
+			Action2:  action2,
+			Reward1:  reward1,
+			Reward2:  reward2,
+			Score1:   g.score1,
+			Score2:   g.score2,
+		}); err != nil {
+			log.Println("replay: failed to record frame:", err)
+		}
+	}
+	if g.netConn != nil {
+		state := pongnet.GameState{
+			Paddle1Y: g.paddle1Y,
+			Paddle2Y: g.paddle2Y,
+			BallX:    g.ballX,
+			BallY:    g.ballY,
+			Score1:   g.score1,
+			Score2:   g.score2,
+			Tick:     g.tick,
+		}
+		if err := g.netConn.WriteState(state); err != nil {
+			log.Println("net: failed to stream game state:", err)
+		}
+	}
+
 	return nil
 }
 
 func (g *Game) resetBall() {
 	g.episodeCount++
-	if g.episodeCount%100 == 0 && g.agent1.epsilon > minEpsilon && g.agent2.epsilon > minEpsilon {
-		g.agent1.epsilon -= epsilonDecayRate
-		g.agent2.epsilon -= epsilonDecayRate
+	if g.episodeCount%100 == 0 {
+		if g.useDQN {
+			if g.dqnAgent1.epsilon > minEpsilon && g.dqnAgent2.epsilon > minEpsilon {
+				g.dqnAgent1.epsilon -= epsilonDecayRate
+				g.dqnAgent2.epsilon -= epsilonDecayRate
+			}
+		} else if g.agent1.epsilon > minEpsilon && g.agent2.epsilon > minEpsilon {
+			g.agent1.epsilon -= epsilonDecayRate
+			g.agent2.epsilon -= epsilonDecayRate
+		}
 	}
 	g.ballX = float64(screenWidth/2)
 	g.ballY = float64(screenHight/2)
@@ -303,6 +570,23 @@ func (g *Game) resetBall() {
 	g.ballVelY = ballSpeedY * (rand.Float64()*2 - 1)
 }
 
+// epsilon1 and epsilon2 report each paddle's current exploration rate from
+// whichever agent kind (tabular or DQN) is active, so callers don't need
+// to special-case useDQN themselves.
+func (g *Game) epsilon1() float64 {
+	if g.useDQN {
+		return g.dqnAgent1.epsilon
+	}
+	return g.agent1.epsilon
+}
+
+func (g *Game) epsilon2() float64 {
+	if g.useDQN {
+		return g.dqnAgent2.epsilon
+	}
+	return g.agent2.epsilon
+}
+
 func intersect(r1MinX, r1MinY, r1MaxX, r1MaxY, r2MinX, r2MinY, r2MaxX, r2MaxY float64) bool {
 	return r1MinX < r2MaxX && r1MaxX > r2MinX && r1MinY < r2MaxY && r1MaxY > r2MinY
 }
@@ -362,7 +646,10 @@ func (a *Agent) selectAction(state int) int {
 	}
 }
 
-func (a *Agent) updateQValue(currentState int, action int, reward float64, nextState int) {
+// updateQValue applies one Q-learning update and returns the TD error
+// (reward + γ·maxNextQ − oldQ) so callers can track learning progress,
+// e.g. the per-episode avgTDError metric.
+func (a *Agent) updateQValue(currentState int, action int, reward float64, nextState int) float64 {
 	maxNextQ := 0.0
 	if _, ok := a.qTable[nextState]; ok {
 		for action := 0; action < 3; action++ {
@@ -374,15 +661,20 @@ func (a *Agent) updateQValue(currentState int, action int, reward float64, nextS
 	}
 
 	oldQValue := a.getQValue(currentState, action)
-	newQValue := oldQValue + a.learningRate*(reward+a.discountRate*maxNextQ-oldQValue)
+	tdError := reward + a.discountRate*maxNextQ - oldQValue
+	newQValue := oldQValue + a.learningRate*tdError
 	a.setQValue(currentState, action, newQValue)
+	return tdError
 }
 
 func (g *Game) Draw(screen *ebiten.Image) {
+	g.handleReplayDebugKeys()
+
 	screen.Fill(color.RGBA{0, 0, 0, 0xff})
 	ebitenutil.DrawRect(screen, 50, g.paddle1Y, paddleWidth, paddleHeight, color.White)
 	ebitenutil.DrawRect(screen, screenWidth-50-paddleWidth, g.paddle2Y, paddleWidth, paddleHeight, color.White)
 	ebitenutil.DrawCircle(screen, g.ballX, g.ballY, ballRadius, color.White)
+	g.drawReplayOverlay(screen)
 
 	scoreText := fmt.Sprintf("AI 1: %d  AI 2: %d", g.score1, g.score2)
 	ebitenutil.DebugPrint(screen, scoreText)
@@ -390,11 +682,15 @@ func (g *Game) Draw(screen *ebiten.Image) {
 	episodeText := fmt.Sprintf("Episode: %d", g.episodeCount)
 	ebitenutil.DebugPrintAt(screen, episodeText, 0, 20)
 
-	epsilonText1 := fmt.Sprintf("Epsilon 1: %.2f", g.agent1.epsilon)
+	epsilonText1 := fmt.Sprintf("Epsilon 1: %.2f", g.epsilon1())
 	ebitenutil.DebugPrintAt(screen, epsilonText1, 0, 40)
 
-	epsilonText2 := fmt.Sprintf("Epsilon 2: %.2f", g.agent2.epsilon)
+	epsilonText2 := fmt.Sprintf("Epsilon 2: %.2f", g.epsilon2())
 	ebitenutil.DebugPrintAt(screen, epsilonText2, 0, 60)
+
+	if g.paused {
+		ebitenutil.DebugPrintAt(screen, "PAUSED (Space to resume)", 0, 100)
+	}
 }
 
 func (g *Game) Layout(outsideWidth, outsideHeight int) (int, int) {
@@ -436,18 +732,180 @@ func (a *Agent) LoadQTable(filename string) error {
 	return nil
 }
 
-func main() {
-	rand.Seed(time.Now().UnixNano())
+// runServer hosts the authoritative simulation: paddle 1 is always driven
+// locally (by its Agent), while paddle 2's action source depends on role
+// ("ai" keeps it local too, "human" reads the local keyboard, "remote"
+// hands control to whichever peer connects).
+func runServer(listenAddr, role, agentKind, rewardKind, debugAddress string, seed int64) {
+	server, err := pongnet.NewServer(listenAddr, 60, screenWidth, screenHight)
+	if err != nil {
+		log.Fatal("net: failed to start server: ", err)
+	}
+	defer server.Close()
+
+	log.Printf("net: listening on %s, waiting for a peer (role=%s)...", listenAddr, role)
+	conn, err := server.Accept()
+	if err != nil {
+		log.Fatal("net: failed to accept peer: ", err)
+	}
+	log.Println("net: peer connected")
+
+	game := NewGameWithAgent(agentKind)
+	game.netConn = conn
+	if err := setupRewardAndMetrics(game, rewardKind, debugAddress); err != nil {
+		log.Fatal(err)
+	}
+	setupReplayRecording(game, seed)
+
+	if game.useDQN && role != "ai" {
+		log.Printf("net: -role=%s has no effect in -agent=dqn mode; paddle 2 stays DQN-controlled", role)
+	}
 
+	switch role {
+	case "remote":
+		inputCh := make(chan int, 1)
+		go func() {
+			for {
+				in, err := conn.ReadInput()
+				if err != nil {
+					log.Println("net: peer disconnected:", err)
+					return
+				}
+				select {
+				case inputCh <- in.Action:
+				default:
+					<-inputCh
+					inputCh <- in.Action
+				}
+			}
+		}()
+		game.actionSource2 = newRemoteActionSource(inputCh)
+	case "human":
+		game.actionSource2 = &keyboardActionSource{upKey: ebiten.KeyUp, downKey: ebiten.KeyDown}
+	case "ai":
+		// Already wired to agent2 by NewGame.
+	default:
+		log.Fatalf("net: unknown -role %q, want ai, human, or remote", role)
+	}
+
+	ebiten.SetWindowSize(screenWidth, screenHight)
+	ebiten.SetWindowTitle("Pong AI (server)")
+	if err := ebiten.RunGame(game); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// runClient connects to a server and renders the authoritative state it
+// streams back, forwarding the local player's keyboard input every tick.
+func runClient(connectAddr string) {
+	client, err := pongnet.Dial(connectAddr)
+	if err != nil {
+		log.Fatal("net: failed to connect: ", err)
+	}
+	defer client.Close()
+
+	remoteGame := newRemoteClientGame(client)
+
+	ebiten.SetWindowSize(client.Handshake.ScreenWidth, client.Handshake.ScreenHight)
+	ebiten.SetWindowTitle("Pong AI (client)")
+	if err := ebiten.RunGame(remoteGame); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// runReplayPlayback renders a recorded run from path instead of training,
+// driven by updateFromReplay rather than the usual simulation step.
+func runReplayPlayback(path string, speed float64) {
 	game := NewGame()
+	if err := setupReplayPlayback(game, path, speed); err != nil {
+		log.Fatal("replay: ", err)
+	}
+
+	ebiten.SetWindowSize(screenWidth, screenHight)
+	ebiten.SetWindowTitle(fmt.Sprintf("Pong AI (replay: %s)", path))
+	if err := ebiten.RunGame(game); err != nil {
+		log.Fatal(err)
+	}
+}
 
-	err1 := game.agent1.LoadQTable("agent1_q_table.json")
-	if err1 != nil {
-		log.Println("Error loading Q-table for agent 1: %w", err1)
+func main() {
+	listenAddr := flag.String("listen", "", "host an authoritative server on this address (e.g. :9000)")
+	connectAddr := flag.String("connect", "", "connect to a server at this address as a remote client")
+	role := flag.String("role", "ai", "who controls paddle 2 on the server: ai, human, or remote")
+	agentKind := flag.String("agent", "qtable", "agent implementation for both paddles: qtable or dqn")
+	trainEpisodes := flag.Int("train-episodes", 0, "run N episodes of headless training, checkpointing to snapshots/ (requires -headless)")
+	headless := flag.Bool("headless", false, "run -train-episodes with no ebiten rendering")
+	vsSnapshot := flag.String("vs-snapshot", "", "drive paddle 2 from a frozen league snapshot instead of live agent2 during -headless training")
+	leagueTournament := flag.Int("league-tournament", 0, "play N random-pair headless matches among snapshots/ and print an Elo leaderboard, then exit")
+	rewardKind := flag.String("reward", "sparse", "reward shaping: sparse, tracking, or rally")
+	debugAddress := flag.String("debug-address", "", "serve live per-episode metrics at this address's /metrics (e.g. :6060)")
+	seed := flag.Int64("seed", 0, "RNG seed for reproducible training (0 uses the current time)")
+	replayPath := flag.String("replay", "", "play back this .rpg file instead of training")
+	replaySpeed := flag.Float64("replay-speed", 1.0, "playback speed multiplier for -replay")
+	mode := flag.String("mode", "ai-vs-ai", "local play mode: ai-vs-ai, human-vs-ai, or human-vs-human")
+	flag.Parse()
+
+	if *agentKind != "qtable" && *agentKind != "dqn" {
+		log.Fatalf("unknown -agent %q, want qtable or dqn", *agentKind)
+	}
+
+	// resolvedSeed is what actually seeds math/rand, so it's also what gets
+	// recorded in every replay.Header.Seed: if -seed is left at 0, a replay
+	// file must still report the time-derived seed that was really used,
+	// not a literal 0 a user could mistake for reproducing the run.
+	resolvedSeed := *seed
+	if resolvedSeed == 0 {
+		resolvedSeed = time.Now().UnixNano()
+	}
+	rand.Seed(resolvedSeed)
+
+	if *replayPath != "" {
+		runReplayPlayback(*replayPath, *replaySpeed)
+		return
+	}
+	if *leagueTournament > 0 {
+		runLeagueTournament("snapshots", *leagueTournament)
+		return
+	}
+	if *headless {
+		if *trainEpisodes <= 0 {
+			log.Fatal("-headless requires -train-episodes > 0")
+		}
+		runHeadlessTraining(*trainEpisodes, *agentKind, *vsSnapshot, *rewardKind, *debugAddress, resolvedSeed)
+		return
 	}
-	err2 := game.agent2.LoadQTable("agent2_q_table.json")
-	if err2 != nil {
-		log.Println("Error loading Q-table for agent 2: %w", err2)
+	if *connectAddr != "" {
+		runClient(*connectAddr)
+		return
+	}
+	if *listenAddr != "" {
+		runServer(*listenAddr, *role, *agentKind, *rewardKind, *debugAddress, resolvedSeed)
+		return
+	}
+
+	game := NewGameWithAgent(*agentKind)
+	if err := setupMode(game, *mode); err != nil {
+		log.Fatal(err)
+	}
+	if err := setupRewardAndMetrics(game, *rewardKind, *debugAddress); err != nil {
+		log.Fatal(err)
+	}
+	setupReplayRecording(game, resolvedSeed)
+
+	if game.useDQN {
+		if err := game.dqnAgent1.LoadWeights("agent1_dqn_weights.json"); err != nil {
+			log.Println("Error loading DQN weights for agent 1:", err)
+		}
+		if err := game.dqnAgent2.LoadWeights("agent2_dqn_weights.json"); err != nil {
+			log.Println("Error loading DQN weights for agent 2:", err)
+		}
+	} else {
+		if err := game.agent1.LoadQTable("agent1_q_table.json"); err != nil {
+			log.Println("Error loading Q-table for agent 1:", err)
+		}
+		if err := game.agent2.LoadQTable("agent2_q_table.json"); err != nil {
+			log.Println("Error loading Q-table for agent 2:", err)
+		}
 	}
 
 	ebiten.SetWindowSize(screenWidth, screenHight)
@@ -457,12 +915,19 @@ func main() {
 		log.Fatal(err)
 	}
 
-	errSave1 := game.agent1.SaveQTable("agent1_q_table.json")
-	if errSave1 != nil {
-		log.Println("Error saving Q-table for agent 1: %w", errSave1)
-	}
-	errSave2 := game.agent2.SaveQTable("agent2_q_table.json")
-	if errSave2 != nil {
-		log.Println("Error saving Q-table for agent 2: %w", errSave2)
+	if game.useDQN {
+		if err := game.dqnAgent1.SaveWeights("agent1_dqn_weights.json"); err != nil {
+			log.Println("Error saving DQN weights for agent 1:", err)
+		}
+		if err := game.dqnAgent2.SaveWeights("agent2_dqn_weights.json"); err != nil {
+			log.Println("Error saving DQN weights for agent 2:", err)
+		}
+	} else {
+		if err := game.agent1.SaveQTable("agent1_q_table.json"); err != nil {
+			log.Println("Error saving Q-table for agent 1:", err)
+		}
+		if err := game.agent2.SaveQTable("agent2_q_table.json"); err != nil {
+			log.Println("Error saving Q-table for agent 2:", err)
+		}
 	}
 }
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+)
+
+// metricsHistorySize bounds how many recent episodes the /metrics HTTP
+// endpoint keeps in memory for live dashboards.
+const metricsHistorySize = 500
+
+// EpisodeMetrics is logged once per point scored, to metrics.jsonl and to
+// the in-memory history served at -debug-address's /metrics.
+type EpisodeMetrics struct {
+	Episode    int     `json:"episode"`
+	Score1     int     `json:"score1"`
+	Score2     int     `json:"score2"`
+	RallyLen   int     `json:"rallyLen"`
+	AvgTDError float64 `json:"avgTDError"`
+	Epsilon    float64 `json:"epsilon"`
+}
+
+// MetricsLogger appends EpisodeMetrics as JSONL to a file and keeps the
+// most recent ones in memory for the /metrics HTTP handler.
+type MetricsLogger struct {
+	file *os.File
+
+	mu      sync.Mutex
+	history []EpisodeMetrics
+}
+
+// NewMetricsLogger opens (creating/appending) path for JSONL writes.
+func NewMetricsLogger(path string) (*MetricsLogger, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open metrics log %s: %w", path, err)
+	}
+	return &MetricsLogger{file: file}, nil
+}
+
+// Log appends m to the JSONL file and the in-memory history ring.
+func (l *MetricsLogger) Log(m EpisodeMetrics) {
+	line, err := json.Marshal(m)
+	if err != nil {
+		fmt.Println("metrics: failed to encode episode:", err)
+		return
+	}
+	if _, err := l.file.Write(append(line, '\n')); err != nil {
+		fmt.Println("metrics: failed to write episode:", err)
+	}
+
+	l.mu.Lock()
+	l.history = append(l.history, m)
+	if len(l.history) > metricsHistorySize {
+		l.history = l.history[len(l.history)-metricsHistorySize:]
+	}
+	l.mu.Unlock()
+}
+
+// Recent returns a copy of the most recently logged episodes.
+func (l *MetricsLogger) Recent() []EpisodeMetrics {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	out := make([]EpisodeMetrics, len(l.history))
+	copy(out, l.history)
+	return out
+}
+
+// ServeHTTP implements http.Handler for the /metrics endpoint, returning
+// the recent episode history as a JSON array.
+func (l *MetricsLogger) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(l.Recent()); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// ServeDebugHTTP starts an HTTP server on addr exposing the metrics
+// logger at /metrics for live dashboards, logging (not fataling) on
+// failure so the game itself keeps running.
+func ServeDebugHTTP(addr string, logger *MetricsLogger) {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", logger)
+	go func() {
+		if err := http.ListenAndServe(addr, mux); err != nil {
+			fmt.Println("debug http server stopped:", err)
+		}
+	}()
+}
@@ -0,0 +1,46 @@
+package net
+
+import (
+	"fmt"
+	"net"
+)
+
+// Client is a remote player's connection to a Server: it performs the
+// handshake on Dial and then exchanges GameState/ClientInput frames.
+type Client struct {
+	conn      *Conn
+	Handshake Handshake
+}
+
+// Dial connects to a Server at addr and reads the handshake it sends.
+func Dial(addr string) (*Client, error) {
+	rawConn, err := net.Dial("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("dial %s: %w", addr, err)
+	}
+	conn := NewConn(rawConn)
+	hs, err := conn.ReadHandshake()
+	if err != nil {
+		rawConn.Close()
+		return nil, fmt.Errorf("read handshake: %w", err)
+	}
+	return &Client{conn: conn, Handshake: hs}, nil
+}
+
+// SendInput forwards the local player's current action to the server.
+func (c *Client) SendInput(action int) error {
+	return c.conn.WriteInput(ClientInput{Action: action})
+}
+
+// ReceiveState blocks for the next authoritative GameState frame.
+func (c *Client) ReceiveState() (GameState, error) {
+	return c.conn.ReadState()
+}
+
+// Close closes the underlying connection.
+func (c *Client) Close() error {
+	if closer, ok := c.conn.rw.(interface{ Close() error }); ok {
+		return closer.Close()
+	}
+	return nil
+}
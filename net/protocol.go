@@ -0,0 +1,135 @@
+// Package net implements the wire protocol for networked two-player Pong:
+// a length-prefixed gob stream carrying an initial Handshake followed by
+// GameState frames (server to client) and ClientInput frames (client to
+// server).
+package net
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"fmt"
+	"io"
+)
+
+// Handshake is exchanged once, right after connect, so both sides agree on
+// tick rate and screen geometry before any GameState/ClientInput frames
+// are sent.
+type Handshake struct {
+	TickRate    int
+	ScreenWidth int
+	ScreenHight int
+}
+
+// GameState is the authoritative simulation snapshot streamed from server
+// to client at the negotiated tick rate.
+type GameState struct {
+	Paddle1Y float64
+	Paddle2Y float64
+	BallX    float64
+	BallY    float64
+	Score1   int
+	Score2   int
+	Tick     uint64
+}
+
+// ClientInput is the action a client forwards to the server for the
+// paddle it controls. Action mirrors the ActionUp/ActionDown/ActionStay
+// constants in package main.
+type ClientInput struct {
+	Action int
+}
+
+// Conn wraps an io.ReadWriter with length-prefixed gob framing so callers
+// can exchange Handshake, GameState, and ClientInput values without
+// worrying about message boundaries.
+type Conn struct {
+	rw  io.ReadWriter
+	enc *gob.Encoder
+}
+
+// NewConn wraps rw (typically a net.Conn) for framed message exchange.
+func NewConn(rw io.ReadWriter) *Conn {
+	return &Conn{rw: rw}
+}
+
+// WriteMessage gob-encodes v and writes it as a single length-prefixed
+// frame.
+func (c *Conn) WriteMessage(v interface{}) error {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(v); err != nil {
+		return fmt.Errorf("encode message: %w", err)
+	}
+	var length [4]byte
+	binary.BigEndian.PutUint32(length[:], uint32(buf.Len()))
+	if _, err := c.rw.Write(length[:]); err != nil {
+		return fmt.Errorf("write frame length: %w", err)
+	}
+	if _, err := c.rw.Write(buf.Bytes()); err != nil {
+		return fmt.Errorf("write frame body: %w", err)
+	}
+	return nil
+}
+
+// maxFrameSize bounds a single decoded frame body, so a corrupt or
+// hostile peer can't force an unbounded allocation via the length prefix.
+// Every message type on this wire (Handshake, GameState, ClientInput) is
+// a handful of fixed-size fields, so this is generous headroom.
+const maxFrameSize = 1 << 20 // 1 MiB
+
+// ReadMessage blocks for the next length-prefixed frame and gob-decodes
+// it into v.
+func (c *Conn) ReadMessage(v interface{}) error {
+	var length [4]byte
+	if _, err := io.ReadFull(c.rw, length[:]); err != nil {
+		return fmt.Errorf("read frame length: %w", err)
+	}
+	size := binary.BigEndian.Uint32(length[:])
+	if size > maxFrameSize {
+		return fmt.Errorf("read frame body: frame size %d exceeds max %d", size, maxFrameSize)
+	}
+	body := make([]byte, size)
+	if _, err := io.ReadFull(c.rw, body); err != nil {
+		return fmt.Errorf("read frame body: %w", err)
+	}
+	if err := gob.NewDecoder(bytes.NewReader(body)).Decode(v); err != nil {
+		return fmt.Errorf("decode message: %w", err)
+	}
+	return nil
+}
+
+// WriteHandshake sends h as the first frame on the connection.
+func (c *Conn) WriteHandshake(h Handshake) error {
+	return c.WriteMessage(h)
+}
+
+// ReadHandshake reads the first frame on the connection as a Handshake.
+func (c *Conn) ReadHandshake() (Handshake, error) {
+	var h Handshake
+	err := c.ReadMessage(&h)
+	return h, err
+}
+
+// WriteState sends the authoritative GameState for the current tick.
+func (c *Conn) WriteState(s GameState) error {
+	return c.WriteMessage(s)
+}
+
+// ReadState reads the next GameState frame.
+func (c *Conn) ReadState() (GameState, error) {
+	var s GameState
+	err := c.ReadMessage(&s)
+	return s, err
+}
+
+// WriteInput sends the client's current action.
+func (c *Conn) WriteInput(in ClientInput) error {
+	return c.WriteMessage(in)
+}
+
+// ReadInput reads the next ClientInput frame.
+func (c *Conn) ReadInput() (ClientInput, error) {
+	var in ClientInput
+	err := c.ReadMessage(&in)
+	return in, err
+}
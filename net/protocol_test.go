@@ -0,0 +1,38 @@
+package net
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+)
+
+func TestConnWriteReadMessageRoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	conn := NewConn(&buf)
+
+	want := GameState{Paddle1Y: 12.5, Paddle2Y: 30, BallX: 100, BallY: 200, Score1: 3, Score2: 1, Tick: 42}
+	if err := conn.WriteMessage(want); err != nil {
+		t.Fatalf("WriteMessage: %v", err)
+	}
+
+	var got GameState
+	if err := conn.ReadMessage(&got); err != nil {
+		t.Fatalf("ReadMessage: %v", err)
+	}
+	if got != want {
+		t.Errorf("round trip mismatch: got %+v, want %+v", got, want)
+	}
+}
+
+func TestConnReadMessageRejectsOversizedFrame(t *testing.T) {
+	var buf bytes.Buffer
+	var length [4]byte
+	binary.BigEndian.PutUint32(length[:], maxFrameSize+1)
+	buf.Write(length[:])
+
+	conn := NewConn(&buf)
+	var v GameState
+	if err := conn.ReadMessage(&v); err == nil {
+		t.Fatal("expected ReadMessage to reject a frame over maxFrameSize, got nil error")
+	}
+}
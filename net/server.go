@@ -0,0 +1,55 @@
+package net
+
+import (
+	"fmt"
+	"net"
+)
+
+// Server accepts a single remote player connection and performs the
+// initial handshake on its behalf. The authoritative simulation loop
+// lives in package main; Server only owns the listener and framing.
+type Server struct {
+	ln          net.Listener
+	tickRate    int
+	screenWidth int
+	screenHight int
+}
+
+// NewServer starts listening on addr for one remote player.
+func NewServer(addr string, tickRate, screenWidth, screenHight int) (*Server, error) {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("listen on %s: %w", addr, err)
+	}
+	return &Server{
+		ln:          ln,
+		tickRate:    tickRate,
+		screenWidth: screenWidth,
+		screenHight: screenHight,
+	}, nil
+}
+
+// Accept blocks for the next incoming connection, sends the handshake,
+// and returns a framed Conn ready for GameState/ClientInput exchange.
+func (s *Server) Accept() (*Conn, error) {
+	rawConn, err := s.ln.Accept()
+	if err != nil {
+		return nil, fmt.Errorf("accept connection: %w", err)
+	}
+	conn := NewConn(rawConn)
+	hs := Handshake{
+		TickRate:    s.tickRate,
+		ScreenWidth: s.screenWidth,
+		ScreenHight: s.screenHight,
+	}
+	if err := conn.WriteHandshake(hs); err != nil {
+		rawConn.Close()
+		return nil, fmt.Errorf("send handshake: %w", err)
+	}
+	return conn, nil
+}
+
+// Close shuts down the listener.
+func (s *Server) Close() error {
+	return s.ln.Close()
+}
@@ -0,0 +1,116 @@
+// Package replay records each training episode as a compact gob log —
+// a Header with the RNG seed and hyperparameters followed by one Frame
+// per tick — so a run can be played back deterministically later.
+package replay
+
+import (
+	"encoding/gob"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// Header is written once at the start of a replay file, before any
+// Frames, so playback can report what produced the run.
+type Header struct {
+	Seed           int64
+	LearningRate   float64
+	DiscountRate   float64
+	InitialEpsilon float64
+}
+
+// Frame is one recorded simulation tick.
+type Frame struct {
+	Tick     uint64
+	BallX    float64
+	BallY    float64
+	Paddle1Y float64
+	Paddle2Y float64
+	Action1  int
+	Action2  int
+	Reward1  float64
+	Reward2  float64
+	Score1   int
+	Score2   int
+}
+
+// Recorder appends Frames to a gob-encoded replay file, preceded by a
+// Header written once on creation.
+type Recorder struct {
+	file *os.File
+	enc  *gob.Encoder
+}
+
+// NewRecorder creates path (and any missing parent directory, e.g.
+// replays/) and writes header as the file's first record.
+func NewRecorder(path string, header Header) (*Recorder, error) {
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return nil, fmt.Errorf("failed to create replay dir %s: %w", dir, err)
+		}
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create replay %s: %w", path, err)
+	}
+	enc := gob.NewEncoder(file)
+	if err := enc.Encode(header); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to write replay header: %w", err)
+	}
+	return &Recorder{file: file, enc: enc}, nil
+}
+
+// RecordFrame appends one tick to the replay.
+func (r *Recorder) RecordFrame(f Frame) error {
+	if err := r.enc.Encode(f); err != nil {
+		return fmt.Errorf("failed to write replay frame: %w", err)
+	}
+	return nil
+}
+
+// Close closes the underlying file.
+func (r *Recorder) Close() error {
+	return r.file.Close()
+}
+
+// Player reads back a Header and its Frames in order.
+type Player struct {
+	file   *os.File
+	dec    *gob.Decoder
+	Header Header
+}
+
+// NewPlayer opens path and decodes its Header.
+func NewPlayer(path string) (*Player, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open replay %s: %w", path, err)
+	}
+	dec := gob.NewDecoder(file)
+	var header Header
+	if err := dec.Decode(&header); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to read replay header: %w", err)
+	}
+	return &Player{file: file, dec: dec, Header: header}, nil
+}
+
+// NextFrame returns the next recorded frame, or io.EOF once the replay
+// is exhausted.
+func (p *Player) NextFrame() (Frame, error) {
+	var f Frame
+	if err := p.dec.Decode(&f); err != nil {
+		if err == io.EOF {
+			return Frame{}, io.EOF
+		}
+		return Frame{}, fmt.Errorf("failed to read replay frame: %w", err)
+	}
+	return f, nil
+}
+
+// Close closes the underlying file.
+func (p *Player) Close() error {
+	return p.file.Close()
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"log"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+
+	"github.com/sumomo-99/go-pong-ai/replay"
+)
+
+// setupReplayRecording enables replay recording on g: every tick is
+// appended to replays/ep{N}.rpg, rolling over to a new file each episode,
+// with seed and hyperparameters captured in each file's Header.
+func setupReplayRecording(g *Game, seed int64) {
+	g.recordingEnabled = true
+	g.recordedEpisode = -1 // force rollReplayFileIfNeeded to open ep0 on the first tick
+	g.replaySeed = seed
+}
+
+// rollReplayFileIfNeeded opens a new replays/ep{N}.rpg whenever the
+// episode counter has advanced since the last recorded frame.
+func (g *Game) rollReplayFileIfNeeded() {
+	if !g.recordingEnabled || g.episodeCount == g.recordedEpisode {
+		return
+	}
+	if g.replayRecorder != nil {
+		if err := g.replayRecorder.Close(); err != nil {
+			log.Println("replay: failed to close replay file:", err)
+		}
+	}
+	path := fmt.Sprintf("replays/ep%d.rpg", g.episodeCount)
+	recorder, err := replay.NewRecorder(path, replay.Header{
+		Seed:           g.replaySeed,
+		LearningRate:   learningRate,
+		DiscountRate:   discountRate,
+		InitialEpsilon: initialEpsilon,
+	})
+	if err != nil {
+		log.Println("replay: failed to open replay file:", err)
+		g.replayRecorder = nil
+		return
+	}
+	g.replayRecorder = recorder
+	g.recordedEpisode = g.episodeCount
+}
+
+// setupReplayPlayback loads path and switches g into non-training
+// playback mode, where Update reads recorded frames instead of stepping
+// the simulation.
+func setupReplayPlayback(g *Game, path string, speed float64) error {
+	player, err := replay.NewPlayer(path)
+	if err != nil {
+		return err
+	}
+	g.replayPlayer = player
+	g.replaySpeed = speed
+	if g.replaySpeed <= 0 {
+		g.replaySpeed = 1
+	}
+	return nil
+}
+
+// updateFromReplay advances playback by replaySpeed frames per tick (one
+// frame per N press while paused), reconstructing paddle/ball position
+// and score straight from the recording.
+func (g *Game) updateFromReplay() error {
+	if g.replayFinished {
+		return nil
+	}
+	if g.replayPaused && !g.replayStepRequested {
+		return nil
+	}
+	g.replayStepRequested = false
+
+	steps := 1
+	if !g.replayPaused {
+		g.replayAccum += g.replaySpeed
+		steps = int(g.replayAccum)
+		g.replayAccum -= float64(steps)
+	}
+
+	for i := 0; i < steps; i++ {
+		frame, err := g.replayPlayer.NextFrame()
+		if err == io.EOF {
+			g.replayFinished = true
+			log.Println("replay: playback finished")
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		g.applyReplayFrame(frame)
+	}
+	return nil
+}
+
+func (g *Game) applyReplayFrame(f replay.Frame) {
+	g.tick = f.Tick
+	g.ballX = f.BallX
+	g.ballY = f.BallY
+	g.paddle1Y = f.Paddle1Y
+	g.paddle2Y = f.Paddle2Y
+	g.prevAction1 = f.Action1
+	g.prevAction2 = f.Action2
+	g.score1 = f.Score1
+	g.score2 = f.Score2
+}
+
+// handleReplayDebugKeys toggles pause (P) and, while paused, single-steps
+// playback (N); called from Draw since it only matters for the debug
+// overlay driving a paused Update.
+func (g *Game) handleReplayDebugKeys() {
+	if g.replayPlayer == nil {
+		return
+	}
+
+	pauseDown := ebiten.IsKeyPressed(ebiten.KeyP)
+	if pauseDown && !g.prevPauseKeyDown {
+		g.replayPaused = !g.replayPaused
+	}
+	g.prevPauseKeyDown = pauseDown
+
+	stepDown := ebiten.IsKeyPressed(ebiten.KeyN)
+	if stepDown && !g.prevStepKeyDown && g.replayPaused {
+		g.replayStepRequested = true
+	}
+	g.prevStepKeyDown = stepDown
+}
+
+// drawReplayOverlay prints playback state so a paused/stepping debug
+// session is visible on screen.
+func (g *Game) drawReplayOverlay(screen *ebiten.Image) {
+	if g.replayPlayer == nil {
+		return
+	}
+	status := "PLAYING"
+	if g.replayFinished {
+		status = "FINISHED"
+	} else if g.replayPaused {
+		status = "PAUSED (N to step)"
+	}
+	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Replay: %s  speed=%.1fx", status, g.replaySpeed), 0, 80)
+}
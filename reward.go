@@ -0,0 +1,123 @@
+package main
+
+import (
+	"fmt"
+	"math"
+)
+
+// RewardShaper computes the reward handed to each paddle's agent for the
+// current tick, selectable via -reward so experiments can compare sparse
+// scoring against denser shaping terms.
+type RewardShaper interface {
+	// Shape returns (reward1, reward2) given this tick's events:
+	// whether each paddle hit the ball, whether either side scored, and
+	// the rally length (consecutive hits since the last point) so far.
+	Shape(g *Game, paddle1Hit, paddle2Hit, scored1, scored2 bool, rallyLen int) (reward1, reward2 float64)
+}
+
+// SparseShaper is the original reward scheme: a small reward/penalty on
+// a paddle hit and a unit reward/penalty on a scored point.
+type SparseShaper struct{}
+
+func (SparseShaper) Shape(g *Game, paddle1Hit, paddle2Hit, scored1, scored2 bool, rallyLen int) (float64, float64) {
+	reward1, reward2 := 0.0, 0.0
+	if paddle1Hit {
+		reward1 += 0.1
+		reward2 -= 0.01
+	}
+	if paddle2Hit {
+		reward2 += 0.1
+		reward1 -= 0.01
+	}
+	if scored1 {
+		reward1 += 1
+		reward2 -= 1
+	}
+	if scored2 {
+		reward2 += 1
+		reward1 -= 1
+	}
+	return reward1, reward2
+}
+
+// paddleTrackingWeight scales the per-tick penalty for a paddle being far
+// from the ball's y-position while the ball approaches it.
+const paddleTrackingWeight = 0.05
+
+// PaddleTrackingShaper adds a dense penalty proportional to how far a
+// paddle is from the ball's height while the ball moves toward it, on
+// top of the sparse hit/score rewards, to give agents a gradient to
+// follow between points.
+type PaddleTrackingShaper struct{}
+
+func (PaddleTrackingShaper) Shape(g *Game, paddle1Hit, paddle2Hit, scored1, scored2 bool, rallyLen int) (float64, float64) {
+	reward1, reward2 := SparseShaper{}.Shape(g, paddle1Hit, paddle2Hit, scored1, scored2, rallyLen)
+
+	if g.ballVelX < 0 {
+		paddle1Center := g.paddle1Y + paddleHeight/2
+		reward1 -= paddleTrackingWeight * math.Abs(g.ballY-paddle1Center) / screenHight
+	}
+	if g.ballVelX > 0 {
+		paddle2Center := g.paddle2Y + paddleHeight/2
+		reward2 -= paddleTrackingWeight * math.Abs(g.ballY-paddle2Center) / screenHight
+	}
+	return reward1, reward2
+}
+
+// rallyBonusPerHit scales the bonus both agents earn on every paddle hit:
+// each hit pays rallyBonusPerHit times the rally length including that
+// hit.
+const rallyBonusPerHit = 0.02
+
+// RallyLengthShaper adds a bonus that scales with the number of
+// consecutive hits in the current rally, on top of the sparse hit/score
+// rewards, to encourage longer exchanges rather than early points.
+type RallyLengthShaper struct{}
+
+func (RallyLengthShaper) Shape(g *Game, paddle1Hit, paddle2Hit, scored1, scored2 bool, rallyLen int) (float64, float64) {
+	reward1, reward2 := SparseShaper{}.Shape(g, paddle1Hit, paddle2Hit, scored1, scored2, rallyLen)
+
+	if paddle1Hit || paddle2Hit {
+		bonus := rallyBonusPerHit * float64(rallyLen)
+		reward1 += bonus
+		reward2 += bonus
+	}
+	return reward1, reward2
+}
+
+// newRewardShaper selects a RewardShaper by the -reward flag value.
+func newRewardShaper(name string) (RewardShaper, error) {
+	switch name {
+	case "sparse":
+		return SparseShaper{}, nil
+	case "tracking":
+		return PaddleTrackingShaper{}, nil
+	case "rally":
+		return RallyLengthShaper{}, nil
+	default:
+		return nil, fmt.Errorf("unknown -reward %q, want sparse, tracking, or rally", name)
+	}
+}
+
+// setupRewardAndMetrics wires g's RewardShaper from rewardKind and always
+// starts logging per-episode metrics to metrics.jsonl; if debugAddress is
+// non-empty, the same metrics are additionally served live at its
+// /metrics endpoint.
+func setupRewardAndMetrics(g *Game, rewardKind, debugAddress string) error {
+	shaper, err := newRewardShaper(rewardKind)
+	if err != nil {
+		return err
+	}
+	g.shaper = shaper
+
+	logger, err := NewMetricsLogger("metrics.jsonl")
+	if err != nil {
+		return err
+	}
+	g.metricsLogger = logger
+
+	if debugAddress != "" {
+		ServeDebugHTTP(debugAddress, logger)
+	}
+	return nil
+}